@@ -0,0 +1,239 @@
+package sumoratinglifecycle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/jph5396/sumomodel"
+)
+
+type (
+	// BoutSource supplies the bouts for a given basho/day pair, letting
+	// BashoCycle load a day's bouts lazily (e.g. from Firestore) instead
+	// of requiring every day's bouts up front.
+	BoutSource interface {
+		BoutsForDay(basho, day int) ([]sumomodel.Bout, error)
+	}
+
+	// BashoCycle owns RikishiData across an entire basho, running each of
+	// its Days days through its own Sumocycle so callers no longer have
+	// to construct a fresh Sumocycle per day and thread RikishiData
+	// through by hand.
+	BashoCycle struct {
+		Basho       int
+		Days        int
+		RikishiData map[int]RikishiData
+		Cycles      []Sumocycle
+
+		ratingSystem RatingSystem
+		parallelism  int
+		source       BoutSource
+
+		beforeBasho func(*BashoCycle) error
+		afterBasho  func(BashoCycle) error
+		beforeDay   func(*Sumocycle) error
+		afterDay    func(Sumocycle) error
+	}
+
+	mapBoutSource map[int][]sumomodel.Bout
+)
+
+// BoutsForDay returns the bouts provided for day, regardless of basho.
+func (m mapBoutSource) BoutsForDay(basho, day int) ([]sumomodel.Bout, error) {
+	bouts, ok := m[day]
+	if !ok {
+		return nil, fmt.Errorf("no bouts provided for day %d", day)
+	}
+	return bouts, nil
+}
+
+// NewBashoCycle creates a BashoCycle that reads each day's bouts from
+// bouts, keyed by day.
+func NewBashoCycle(basho int, days int, rikishi map[int]RikishiData, bouts map[int][]sumomodel.Bout) *BashoCycle {
+	return NewBashoCycleFromSource(basho, days, rikishi, mapBoutSource(bouts))
+}
+
+// NewBashoCycleFromSource creates a BashoCycle that reads each day's bouts
+// from source, letting bouts be lazy-loaded (e.g. from Firestore) instead
+// of provided all at once.
+func NewBashoCycleFromSource(basho int, days int, rikishi map[int]RikishiData, source BoutSource) *BashoCycle {
+	return &BashoCycle{
+		Basho:       basho,
+		Days:        days,
+		RikishiData: rikishi,
+		source:      source,
+	}
+}
+
+// RatingSystem sets the RatingSystem used for every day's Sumocycle.
+func (b *BashoCycle) RatingSystem(rs RatingSystem) {
+	b.ratingSystem = rs
+}
+
+// Parallelism sets the Sumocycle.Parallelism applied to every day.
+func (b *BashoCycle) Parallelism(n int) {
+	b.parallelism = n
+}
+
+// BeforeBasho sets the function that is executed before day 1 begins.
+func (b *BashoCycle) BeforeBasho(f func(*BashoCycle) error) {
+	b.beforeBasho = f
+}
+
+// AfterBasho sets the function that is executed once every day has been
+// processed. It is a good time to save the final leaderboard.
+func (b *BashoCycle) AfterBasho(f func(BashoCycle) error) {
+	b.afterBasho = f
+}
+
+// BeforeDay sets the function applied as every day's Sumocycle.BeforeDay.
+func (b *BashoCycle) BeforeDay(f func(*Sumocycle) error) {
+	b.beforeDay = f
+}
+
+// AfterDay sets the function applied as every day's Sumocycle.AfterDay.
+func (b *BashoCycle) AfterDay(f func(Sumocycle) error) {
+	b.afterDay = f
+}
+
+// Begin runs the basho day 1..Days. It is equivalent to
+// BeginContext(context.Background()).
+func (b *BashoCycle) Begin() error {
+	return b.BeginContext(context.Background())
+}
+
+// BeginContext runs the basho day 1..Days, aborting promptly once ctx is
+// cancelled or a hook returns an error. Errors encountered on a given day
+// are wrapped with that day's number via fmt.Errorf("day %d: %w", day, err).
+// Calling BeginContext again after a failed run resumes at the first day
+// that doesn't yet have a completed Cycles entry. Because each day's
+// Sumocycle runs against a private copy of RikishiData, a day that aborts
+// partway through never mutates b.RikishiData, so the retry re-runs that
+// day's bouts from the same starting ratings instead of double-applying
+// whatever part of the day already landed.
+func (b *BashoCycle) BeginContext(ctx context.Context) error {
+	if b.beforeBasho != nil {
+		if err := b.beforeBasho(b); err != nil {
+			return err
+		}
+	}
+
+	for day := len(b.Cycles) + 1; day <= b.Days; day++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+
+		bouts, err := b.source.BoutsForDay(b.Basho, day)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+
+		dayRikishi := make(map[int]RikishiData, len(b.RikishiData))
+		for id, data := range b.RikishiData {
+			dayRikishi[id] = data
+		}
+
+		cycle := NewSumocycle(b.Basho, day, dayRikishi, bouts)
+		cycle.RatingSystem(b.ratingSystem)
+		if b.parallelism > 1 {
+			cycle.Parallelism(b.parallelism)
+		}
+		if b.beforeDay != nil {
+			cycle.BeforeDay(b.beforeDay)
+		}
+		if b.afterDay != nil {
+			cycle.AfterDay(b.afterDay)
+		}
+
+		if err := cycle.BeginContext(ctx); err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+
+		b.RikishiData = cycle.RikishiData
+		b.Cycles = append(b.Cycles, cycle)
+	}
+
+	if b.afterBasho != nil {
+		if err := b.afterBasho(*b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Leaderboard returns the top rikishi sorted by current rating
+// descending, using a stable sort so rikishi that tie break by rank then
+// name. A non-positive top returns every rikishi. Leaderboard returns nil
+// if no RatingSystem has been set yet.
+func (b *BashoCycle) Leaderboard(top int) []RikishiData {
+	if b.ratingSystem == nil {
+		return nil
+	}
+
+	list := make([]RikishiData, 0, len(b.RikishiData))
+	for _, data := range b.RikishiData {
+		list = append(list, data)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if cmp := b.ratingSystem.Compare(list[i].Rating, list[j].Rating); cmp != 0 {
+			return cmp > 0
+		}
+		if list[i].Rikishi.Rank != list[j].Rikishi.Rank {
+			return rankLess(list[i].Rikishi.Rank, list[j].Rikishi.Rank)
+		}
+		return list[i].Rikishi.Name < list[j].Rikishi.Name
+	})
+
+	if top > 0 && top < len(list) {
+		list = list[:top]
+	}
+
+	return list
+}
+
+// rankLess orders sumo ranks like "M2"/"M10" numerically by their trailing
+// number rather than lexically, so "M2" sorts above "M10" as intended.
+func rankLess(a, b string) bool {
+	aPrefix, aNum := splitRank(a)
+	bPrefix, bNum := splitRank(b)
+	if aPrefix != bPrefix {
+		return aPrefix < bPrefix
+	}
+	return aNum < bNum
+}
+
+// splitRank splits a rank like "M10" into its letter prefix and trailing
+// number. Ranks with no trailing number (e.g. "Y" for Yokozuna) get 0.
+func splitRank(rank string) (string, int) {
+	i := len(rank)
+	for i > 0 && rank[i-1] >= '0' && rank[i-1] <= '9' {
+		i--
+	}
+
+	num, _ := strconv.Atoi(rank[i:])
+	return rank[:i], num
+}
+
+// RatingHistory reconstructs rikishiID's per-bout trajectory across every
+// day of the basho processed so far, by scanning each day's Sumocycle
+// Resultlist in order.
+func (b *BashoCycle) RatingHistory(rikishiID int) []RikishiBoutResult {
+	var history []RikishiBoutResult
+
+	for _, cycle := range b.Cycles {
+		for _, result := range cycle.Resultlist {
+			switch rikishiID {
+			case result.East.RikishiID:
+				history = append(history, result.East)
+			case result.West.RikishiID:
+				history = append(history, result.West)
+			}
+		}
+	}
+
+	return history
+}
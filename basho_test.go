@@ -0,0 +1,145 @@
+package sumoratinglifecycle
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/jph5396/sumomodel"
+)
+
+func threeRikishi() map[int]RikishiData {
+	rs := EloSystem{K: 32}
+	return map[int]RikishiData{
+		1: {Rikishi: sumomodel.Rikishi{Id: 1, Name: "one"}, Rating: rs.InitialRating()},
+		2: {Rikishi: sumomodel.Rikishi{Id: 2, Name: "two"}, Rating: rs.InitialRating()},
+		3: {Rikishi: sumomodel.Rikishi{Id: 3, Name: "three"}, Rating: rs.InitialRating()},
+	}
+}
+
+func twoBoutDay(day int) map[int][]sumomodel.Bout {
+	return map[int][]sumomodel.Bout{
+		day: {
+			{BashoID: 1, Day: day, Boutnum: 1, EastRikishiID: 1, WestRikishiID: 2, EastWin: true},
+			{BashoID: 1, Day: day, Boutnum: 2, EastRikishiID: 1, WestRikishiID: 3, EastWin: true},
+		},
+	}
+}
+
+// cancelingElo wraps EloSystem and cancels ctx partway through a day so
+// tests can exercise BeginContext's abort/retry path deterministically.
+type cancelingElo struct {
+	EloSystem
+	calls      int
+	cancelAt   int
+	cancelFunc context.CancelFunc
+}
+
+func (c *cancelingElo) Update(self, opponent Rating, selfWon bool) Rating {
+	c.calls++
+	if c.calls == c.cancelAt && c.cancelFunc != nil {
+		c.cancelFunc()
+	}
+	return c.EloSystem.Update(self, opponent, selfWon)
+}
+
+func TestBashoCycleRetryDoesNotDoubleApplyRatings(t *testing.T) {
+	// Reference run: the same day, uninterrupted, gives the correct
+	// single-application rating.
+	ref := NewBashoCycle(1, 1, threeRikishi(), twoBoutDay(1))
+	ref.RatingSystem(EloSystem{K: 32})
+	if err := ref.Begin(); err != nil {
+		t.Fatalf("reference run: %v", err)
+	}
+	want := EloSystem{K: 32}.Display(ref.RikishiData[1].Rating)
+
+	// Subject run: cancel ctx after the first bout's two Update calls
+	// (east + west), so the day aborts before the second bout.
+	ctx, cancel := context.WithCancel(context.Background())
+	rs := &cancelingElo{EloSystem: EloSystem{K: 32}, cancelAt: 2, cancelFunc: cancel}
+
+	subject := NewBashoCycle(1, 1, threeRikishi(), twoBoutDay(1))
+	subject.RatingSystem(rs)
+
+	if err := subject.BeginContext(ctx); err == nil {
+		t.Fatal("expected the cancelled day to return an error")
+	}
+	if len(subject.Cycles) != 0 {
+		t.Fatalf("day should not be marked complete after an aborted run, got %d Cycles", len(subject.Cycles))
+	}
+	if got := rs.Display(subject.RikishiData[1].Rating); got != 1500 {
+		t.Fatalf("RikishiData must be untouched after an aborted day, got rating %v", got)
+	}
+
+	// Retry with a fresh, uncancelled context. The day should re-run
+	// cleanly from the original ratings, not from whatever a partial
+	// first attempt already wrote back.
+	if err := subject.BeginContext(context.Background()); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+
+	got := rs.Display(subject.RikishiData[1].Rating)
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("retry double-applied ratings: got %v, want %v", got, want)
+	}
+}
+
+func TestBashoCycleLeaderboardOrdering(t *testing.T) {
+	rs := EloSystem{K: 32}
+	rikishi := map[int]RikishiData{
+		1: {Rikishi: sumomodel.Rikishi{Id: 1, Name: "low", Rank: "M5"}, Rating: float64(1400)},
+		2: {Rikishi: sumomodel.Rikishi{Id: 2, Name: "high", Rank: "M1"}, Rating: float64(1600)},
+		3: {Rikishi: sumomodel.Rikishi{Id: 3, Name: "tieA", Rank: "M2"}, Rating: float64(1500)},
+		4: {Rikishi: sumomodel.Rikishi{Id: 4, Name: "tieB", Rank: "M10"}, Rating: float64(1500)},
+	}
+
+	b := NewBashoCycle(1, 0, rikishi, nil)
+	b.RatingSystem(rs)
+
+	board := b.Leaderboard(0)
+	if len(board) != 4 {
+		t.Fatalf("expected 4 rikishi, got %d", len(board))
+	}
+
+	gotOrder := []int{board[0].Rikishi.Id, board[1].Rikishi.Id, board[2].Rikishi.Id, board[3].Rikishi.Id}
+	wantOrder := []int{2, 3, 4, 1}
+	for i := range wantOrder {
+		if gotOrder[i] != wantOrder[i] {
+			t.Fatalf("leaderboard order = %v, want %v", gotOrder, wantOrder)
+		}
+	}
+
+	if top := b.Leaderboard(1); len(top) != 1 || top[0].Rikishi.Id != 2 {
+		t.Fatalf("Leaderboard(1) = %v, want just rikishi 2", top)
+	}
+}
+
+func TestBashoCycleLeaderboardNoRatingSystem(t *testing.T) {
+	b := NewBashoCycle(1, 0, threeRikishi(), nil)
+	if board := b.Leaderboard(0); board != nil {
+		t.Fatalf("expected nil leaderboard before a RatingSystem is set, got %v", board)
+	}
+}
+
+func TestBashoCycleRatingHistoryOrdering(t *testing.T) {
+	b := NewBashoCycle(1, 2, threeRikishi(), map[int][]sumomodel.Bout{
+		1: {{BashoID: 1, Day: 1, Boutnum: 1, EastRikishiID: 1, WestRikishiID: 2, EastWin: true}},
+		2: {{BashoID: 1, Day: 2, Boutnum: 1, EastRikishiID: 3, WestRikishiID: 1, EastWin: true}},
+	})
+	b.RatingSystem(EloSystem{K: 32})
+
+	if err := b.Begin(); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	history := b.RatingHistory(1)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history entries for rikishi 1, got %d", len(history))
+	}
+	if history[0].ScorePre != 1500 {
+		t.Fatalf("day 1 entry should start from the initial rating, got %v", history[0].ScorePre)
+	}
+	if history[1].ScorePre != history[0].ScorePost {
+		t.Fatalf("day 2 entry should pick up where day 1 left off: day1 post=%v day2 pre=%v", history[0].ScorePost, history[1].ScorePre)
+	}
+}
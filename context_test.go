@@ -0,0 +1,100 @@
+package sumoratinglifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jph5396/sumomodel"
+)
+
+func twoRikishiOneBoutDay() (map[int]RikishiData, []sumomodel.Bout) {
+	rs := EloSystem{K: 32}
+	rikishi := map[int]RikishiData{
+		1: {Rikishi: sumomodel.Rikishi{Id: 1, Name: "one"}, Rating: rs.InitialRating()},
+		2: {Rikishi: sumomodel.Rikishi{Id: 2, Name: "two"}, Rating: rs.InitialRating()},
+	}
+	bouts := []sumomodel.Bout{
+		{BashoID: 1, Day: 1, Boutnum: 1, EastRikishiID: 1, WestRikishiID: 2, EastWin: true},
+	}
+	return rikishi, bouts
+}
+
+func TestBeginContextAbortsOnCancelledContext(t *testing.T) {
+	rikishi, bouts := twoRikishiOneBoutDay()
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(EloSystem{K: 32})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.BeginContext(ctx)
+	if err == nil {
+		t.Fatal("expected BeginContext to return an error for an already-cancelled context")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error to wrap context.Canceled, got %v", err)
+	}
+	if len(s.Resultlist) != 0 {
+		t.Fatalf("no bouts should have been processed, got %d results", len(s.Resultlist))
+	}
+}
+
+func TestBeginContextWrapsHookErrorWithBoutIndex(t *testing.T) {
+	rikishi, bouts := twoRikishiOneBoutDay()
+	// Add a second bout so BeforeBout on index 1 is the one that fails.
+	bouts = append(bouts, sumomodel.Bout{BashoID: 1, Day: 1, Boutnum: 2, EastRikishiID: 1, WestRikishiID: 2, EastWin: false})
+
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(EloSystem{K: 32})
+
+	boom := errors.New("boom")
+	s.BeforeBout(func(_ *sumomodel.Bout, index int) error {
+		if index == 1 {
+			return boom
+		}
+		return nil
+	})
+
+	err := s.Begin()
+	if err == nil {
+		t.Fatal("expected an error from the BeforeBout hook")
+	}
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected wrapped error to unwrap to boom, got %v", err)
+	}
+
+	wantPrefix := fmt.Sprintf("bout %d:", 1)
+	if got := err.Error(); len(got) < len(wantPrefix) || got[:len(wantPrefix)] != wantPrefix {
+		t.Fatalf("expected error to start with %q, got %q", wantPrefix, got)
+	}
+
+	// The first bout should have been fully applied before the second
+	// bout's hook aborted the cycle.
+	if len(s.Resultlist) != 1 {
+		t.Fatalf("expected exactly 1 bout result before the abort, got %d", len(s.Resultlist))
+	}
+}
+
+func TestSnapshotIsIndependentOfFurtherMutation(t *testing.T) {
+	rikishi, bouts := twoRikishiOneBoutDay()
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(EloSystem{K: 32})
+
+	if err := s.Begin(); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	snapRikishi, snapResults := s.Snapshot()
+
+	s.RikishiData[1] = RikishiData{Rikishi: sumomodel.Rikishi{Id: 1}, Rating: float64(9999)}
+	s.Resultlist = append(s.Resultlist, BoutResult{})
+
+	if snapRikishi[1].Rating == float64(9999) {
+		t.Fatalf("Snapshot's RikishiData should not be affected by later mutation of the live map")
+	}
+	if len(snapResults) != 1 {
+		t.Fatalf("Snapshot's Resultlist should not grow when the live Resultlist grows, got len %d", len(snapResults))
+	}
+}
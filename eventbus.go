@@ -0,0 +1,118 @@
+package sumoratinglifecycle
+
+import (
+	"sync"
+
+	"github.com/jph5396/sumomodel"
+)
+
+type (
+	// Topic identifies a lifecycle stage that subscribers can listen on.
+	Topic string
+
+	// Handler is invoked with the event payload for the topic it was
+	// subscribed to. Returning a non-nil error aborts the in-progress
+	// Sumocycle.
+	Handler func(payload interface{}) error
+
+	// Unsubscribe removes the subscription it was returned for. Calling it
+	// more than once is a no-op.
+	Unsubscribe func()
+
+	// BeforeDayEvent is published on the BeforeDay topic before any bouts
+	// for the day are processed.
+	BeforeDayEvent struct {
+		Cycle *Sumocycle
+	}
+
+	// BeforeBoutEvent is published on the BeforeBout topic right before a
+	// bout is calculated.
+	BeforeBoutEvent struct {
+		Bout  *sumomodel.Bout
+		Index int
+	}
+
+	// AfterBoutEvent is published on the AfterBout topic once a bout's
+	// result has been calculated.
+	AfterBoutEvent struct {
+		Result BoutResult
+	}
+
+	// AfterDayEvent is published on the AfterDay topic once every bout for
+	// the day has been processed.
+	AfterDayEvent struct {
+		Cycle Sumocycle
+	}
+
+	// EventBus lets independent subscribers (persistence, metrics,
+	// notifications, etc.) observe Sumocycle lifecycle stages without
+	// bundling that logic into the single legacy hook functions.
+	EventBus struct {
+		mu       sync.Mutex
+		nextID   int
+		handlers map[Topic][]*subscriber
+	}
+
+	subscriber struct {
+		id      int
+		handler Handler
+	}
+)
+
+// Lifecycle topics published by Sumocycle.Begin / BeginContext.
+const (
+	BeforeDay  Topic = "BeforeDay"
+	BeforeBout Topic = "BeforeBout"
+	AfterBout  Topic = "AfterBout"
+	AfterDay   Topic = "AfterDay"
+)
+
+// NewEventBus creates an empty EventBus ready for subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[Topic][]*subscriber),
+	}
+}
+
+// Subscribe registers handler to be invoked, in registration order, every
+// time payload is published on topic. The returned Unsubscribe removes the
+// handler.
+func (b *EventBus) Subscribe(topic Topic, handler Handler) Unsubscribe {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.handlers[topic] = append(b.handlers[topic], &subscriber{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.handlers[topic]
+		for i, sub := range subs {
+			if sub.id == id {
+				b.handlers[topic] = append(subs[:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish invokes every subscriber registered for topic, in registration
+// order, with payload. It stops and returns the first error a handler
+// returns.
+func (b *EventBus) Publish(topic Topic, payload interface{}) error {
+	b.mu.Lock()
+	subs := make([]*subscriber, len(b.handlers[topic]))
+	copy(subs, b.handlers[topic])
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if err := sub.handler(payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package sumoratinglifecycle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventBusInvokesSubscribersInRegistrationOrder(t *testing.T) {
+	bus := NewEventBus()
+
+	var order []int
+	bus.Subscribe(BeforeDay, func(interface{}) error {
+		order = append(order, 1)
+		return nil
+	})
+	bus.Subscribe(BeforeDay, func(interface{}) error {
+		order = append(order, 2)
+		return nil
+	})
+	bus.Subscribe(BeforeDay, func(interface{}) error {
+		order = append(order, 3)
+		return nil
+	})
+
+	if err := bus.Publish(BeforeDay, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	want := []int{1, 2, 3}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestEventBusPublishAbortsOnFirstError(t *testing.T) {
+	bus := NewEventBus()
+
+	boom := errors.New("boom")
+	var calledThird bool
+
+	bus.Subscribe(BeforeBout, func(interface{}) error { return nil })
+	bus.Subscribe(BeforeBout, func(interface{}) error { return boom })
+	bus.Subscribe(BeforeBout, func(interface{}) error {
+		calledThird = true
+		return nil
+	})
+
+	err := bus.Publish(BeforeBout, nil)
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected Publish to return boom, got %v", err)
+	}
+	if calledThird {
+		t.Fatalf("subscriber after the erroring one should not have run")
+	}
+}
+
+func TestEventBusUnsubscribeStopsFurtherDelivery(t *testing.T) {
+	bus := NewEventBus()
+
+	calls := 0
+	unsubscribe := bus.Subscribe(AfterBout, func(interface{}) error {
+		calls++
+		return nil
+	})
+
+	if err := bus.Publish(AfterBout, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	unsubscribe()
+	if err := bus.Publish(AfterBout, nil); err != nil {
+		t.Fatalf("Publish after unsubscribe: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 call before unsubscribe, got %d", calls)
+	}
+
+	// Unsubscribing twice must be a no-op, not a panic.
+	unsubscribe()
+}
+
+func TestSumocycleBeginAbortsWhenASubscriberErrors(t *testing.T) {
+	rikishi, bouts := twoRikishiOneBoutDay()
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(EloSystem{K: 32})
+
+	boom := errors.New("boom")
+	s.Events().Subscribe(AfterBout, func(interface{}) error {
+		return boom
+	})
+
+	err := s.Begin()
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected Begin to surface the subscriber error, got %v", err)
+	}
+}
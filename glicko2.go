@@ -0,0 +1,147 @@
+package sumoratinglifecycle
+
+import "math"
+
+// glicko2Scale converts between the public Glicko rating/RD scale and the
+// internal Glicko-2 mu/phi scale used by the update math below.
+const glicko2Scale = 173.7178
+
+// Glicko2Rating is the Rating carried by Glicko2System: a rating, its
+// deviation (RD), and its volatility, all on the public Glicko scale.
+type Glicko2Rating struct {
+	R     float64
+	RD    float64
+	Sigma float64
+}
+
+// Glicko2System implements the Glicko-2 rating system described at
+// http://www.glicko.net/glicko/glicko2.pdf. Tau constrains how much
+// volatility is allowed to change; 0.3-1.2 is the range Glickman suggests,
+// smaller values trusting recent bouts less.
+type Glicko2System struct {
+	Tau float64
+}
+
+// InitialRating returns Glickman's suggested defaults: rating 1500, RD 350,
+// sigma 0.06.
+func (g Glicko2System) InitialRating() Rating {
+	return Glicko2Rating{R: 1500, RD: 350, Sigma: 0.06}
+}
+
+// Update runs a single-opponent rating period through BatchUpdate. It lets
+// Glicko2System satisfy RatingSystem for callers that only need one bout's
+// worth of movement; Begin prefers BatchUpdate whenever a whole day's
+// results are available, since Glicko-2 is defined in terms of a rating
+// period, not a single bout.
+func (g Glicko2System) Update(self, opponent Rating, selfWon bool) Rating {
+	return g.BatchUpdate(self, []BatchResult{{Opponent: opponent, Won: selfWon}})
+}
+
+// BatchUpdate applies the Glicko-2 algorithm for one rating period made up
+// of results.
+func (g Glicko2System) BatchUpdate(self Rating, results []BatchResult) Rating {
+	r := self.(Glicko2Rating)
+
+	if len(results) == 0 {
+		// No bouts this period: RD still grows by the volatility (with no
+		// upper bound), but there is nothing else to update.
+		phi := r.RD / glicko2Scale
+		phiStar := math.Sqrt(phi*phi + r.Sigma*r.Sigma)
+		return Glicko2Rating{R: r.R, RD: phiStar * glicko2Scale, Sigma: r.Sigma}
+	}
+
+	mu := (r.R - 1500) / glicko2Scale
+	phi := r.RD / glicko2Scale
+
+	var varianceInv, deltaSum float64
+	for _, res := range results {
+		opp := res.Opponent.(Glicko2Rating)
+		muJ := (opp.R - 1500) / glicko2Scale
+		phiJ := opp.RD / glicko2Scale
+
+		gPhiJ := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-gPhiJ*(mu-muJ)))
+
+		score := 0.0
+		if res.Won {
+			score = 1.0
+		}
+
+		varianceInv += gPhiJ * gPhiJ * e * (1 - e)
+		deltaSum += gPhiJ * (score - e)
+	}
+
+	v := 1 / varianceInv
+	delta := v * deltaSum
+
+	sigmaPrime := g.newSigma(phi, v, delta, r.Sigma)
+
+	phiStar := math.Sqrt(phi*phi + sigmaPrime*sigmaPrime)
+	phiPrime := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	muPrime := mu + phiPrime*phiPrime*deltaSum
+
+	return Glicko2Rating{
+		R:     glicko2Scale*muPrime + 1500,
+		RD:    phiPrime * glicko2Scale,
+		Sigma: sigmaPrime,
+	}
+}
+
+// newSigma solves for the updated volatility using the Illinois algorithm,
+// per step 5 of the Glicko-2 paper.
+func (g Glicko2System) newSigma(phi, v, delta, sigma float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * math.Pow(phi*phi+v+ex, 2)
+		return num/den - (x-a)/(g.Tau*g.Tau)
+	}
+
+	const epsilon = 0.000001
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*g.Tau) < 0 {
+			k++
+		}
+		B = a - k*g.Tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
+
+// Compare ranks two Glicko-2 ratings by their public rating value.
+func (g Glicko2System) Compare(a, b Rating) int {
+	return compareFloat64(a.(Glicko2Rating).R, b.(Glicko2Rating).R)
+}
+
+// Display returns the public Glicko rating value.
+func (g Glicko2System) Display(r Rating) float64 {
+	return r.(Glicko2Rating).R
+}
+
+// BatchesPerDay is true: Glicko-2 is defined over a rating period, so
+// Begin accumulates a day's bouts per rikishi and applies them together
+// via BatchUpdate.
+func (g Glicko2System) BatchesPerDay() bool {
+	return true
+}
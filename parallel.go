@@ -0,0 +1,125 @@
+package sumoratinglifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jph5396/sumomodel"
+)
+
+// partitionBouts splits bouts into batches where no rikishi ID appears
+// twice within a batch, so every bout in a batch can be calculated
+// concurrently without two goroutines racing to update the same rikishi.
+// A day of sumo already has this property across the whole BoutList (no
+// rikishi fights twice in a day within a division), so in practice this
+// usually returns a single batch; the partition only matters if that
+// invariant doesn't hold. A bout always lands in a later batch than every
+// earlier bout sharing one of its rikishi, so each rikishi's updates are
+// applied in original bout order.
+func partitionBouts(bouts []sumomodel.Bout) [][]int {
+	var batches [][]int
+	// next[id] is the earliest batch a bout involving id may join: one
+	// past the batch holding that rikishi's latest bout so far.
+	next := map[int]int{}
+
+	for i, bout := range bouts {
+		b := next[bout.EastRikishiID]
+		if w := next[bout.WestRikishiID]; w > b {
+			b = w
+		}
+		if b == len(batches) {
+			batches = append(batches, nil)
+		}
+		batches[b] = append(batches[b], i)
+		next[bout.EastRikishiID] = b + 1
+		next[bout.WestRikishiID] = b + 1
+	}
+
+	return batches
+}
+
+// runParallel computes s.BoutList across s.parallelism goroutines. It
+// partitions the day into batches of disjoint bouts, fires prebout/
+// BeforeBout in original order before dispatching a batch, computes every
+// bout in the batch concurrently under a mutex that guards s.RikishiData
+// (and the batching-rating-system pending map) from concurrent reads and
+// writes, then flushes postbout/AfterBout and Resultlist once every bout
+// has a result, in original bout index order.
+func (s *Sumocycle) runParallel(ctx context.Context, pending map[int][]BatchResult, isBatch bool) error {
+	batches := partitionBouts(s.BoutList)
+
+	var mu sync.Mutex
+	results := make([]BoutResult, len(s.BoutList))
+
+	for _, batch := range batches {
+		if err := s.runBatch(ctx, batch, &mu, pending, isBatch, results); err != nil {
+			return err
+		}
+	}
+
+	for i, result := range results {
+		if s.postbout != nil {
+			if err := s.postbout(result); err != nil {
+				return fmt.Errorf("bout %d: %w", i, err)
+			}
+		}
+		if err := s.Events().Publish(AfterBout, AfterBoutEvent{Result: result}); err != nil {
+			return fmt.Errorf("bout %d: %w", i, err)
+		}
+		s.Resultlist = append(s.Resultlist, result)
+	}
+
+	return nil
+}
+
+// runBatch fires the before-bout hooks for batch in order, then computes
+// every bout it contains across s.parallelism worker goroutines, writing
+// each result into results at its original bout index.
+func (s *Sumocycle) runBatch(ctx context.Context, batch []int, mu *sync.Mutex, pending map[int][]BatchResult, isBatch bool, results []BoutResult) error {
+	for _, idx := range batch {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("bout %d: %w", idx, err)
+		}
+
+		bout := s.BoutList[idx]
+		if s.prebout != nil {
+			if err := s.prebout(&bout, idx); err != nil {
+				return fmt.Errorf("bout %d: %w", idx, err)
+			}
+		}
+		if err := s.Events().Publish(BeforeBout, BeforeBoutEvent{Bout: &bout, Index: idx}); err != nil {
+			return fmt.Errorf("bout %d: %w", idx, err)
+		}
+	}
+
+	sem := make(chan struct{}, s.parallelism)
+	errs := make([]error, len(batch))
+
+	var wg sync.WaitGroup
+	for pos, idx := range batch {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(pos, idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := s.calculateBout(s.BoutList[idx], mu, pending, isBatch)
+			if err != nil {
+				errs[pos] = fmt.Errorf("bout %d: %w", idx, err)
+				return
+			}
+			results[idx] = result
+		}(pos, idx)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
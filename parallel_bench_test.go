@@ -0,0 +1,70 @@
+package sumoratinglifecycle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jph5396/sumomodel"
+)
+
+// benchRikishi builds rikishi data for a single makuuchi-sized division
+// (42 wrestlers, 21 bouts a day).
+func benchRikishi() map[int]RikishiData {
+	rikishi := make(map[int]RikishiData, 42)
+	rs := EloSystem{K: 32}
+	for id := 1; id <= 42; id++ {
+		rikishi[id] = RikishiData{
+			Rikishi: sumomodel.Rikishi{Id: id, Name: fmt.Sprintf("rikishi-%d", id)},
+			Rating:  rs.InitialRating(),
+		}
+	}
+	return rikishi
+}
+
+// benchDayBouts pairs up the division for one day, alternating who has
+// the edge so Begin has real rating movement to calculate.
+func benchDayBouts(day int) []sumomodel.Bout {
+	bouts := make([]sumomodel.Bout, 0, 21)
+	for i := 1; i <= 42; i += 2 {
+		bouts = append(bouts, sumomodel.Bout{
+			BashoID:       1,
+			Day:           day,
+			Boutnum:       len(bouts) + 1,
+			EastRikishiID: i,
+			WestRikishiID: i + 1,
+			EastWin:       i%3 != 0,
+			WestWin:       i%3 == 0,
+		})
+	}
+	return bouts
+}
+
+// runBasho runs a full 15-day basho's worth of Begin calls at the given
+// parallelism, reusing a single rikishi pool the way BashoCycle does.
+func runBasho(b *testing.B, parallelism int) {
+	for n := 0; n < b.N; n++ {
+		rikishi := benchRikishi()
+		for day := 1; day <= 15; day++ {
+			cycle := NewSumocycle(1, day, rikishi, benchDayBouts(day))
+			cycle.RatingSystem(EloSystem{K: 32})
+			if parallelism > 1 {
+				cycle.Parallelism(parallelism)
+			}
+			if err := cycle.Begin(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkBeginSequential(b *testing.B) {
+	runBasho(b, 1)
+}
+
+func BenchmarkBeginParallel4(b *testing.B) {
+	runBasho(b, 4)
+}
+
+func BenchmarkBeginParallel8(b *testing.B) {
+	runBasho(b, 8)
+}
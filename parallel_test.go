@@ -0,0 +1,110 @@
+package sumoratinglifecycle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jph5396/sumomodel"
+)
+
+func divisionRikishi(n int) map[int]RikishiData {
+	rs := EloSystem{K: 32}
+	rikishi := make(map[int]RikishiData, n)
+	for id := 1; id <= n; id++ {
+		rikishi[id] = RikishiData{
+			Rikishi: sumomodel.Rikishi{Id: id, Name: fmt.Sprintf("rikishi-%d", id)},
+			Rating:  rs.InitialRating(),
+		}
+	}
+	return rikishi
+}
+
+func divisionBouts(n int) []sumomodel.Bout {
+	bouts := make([]sumomodel.Bout, 0, n/2)
+	for i := 1; i <= n; i += 2 {
+		bouts = append(bouts, sumomodel.Bout{
+			BashoID:       1,
+			Day:           1,
+			Boutnum:       len(bouts) + 1,
+			EastRikishiID: i,
+			WestRikishiID: i + 1,
+			EastWin:       i%3 != 0,
+			WestWin:       i%3 == 0,
+		})
+	}
+	return bouts
+}
+
+// TestParallelMatchesSequentialResults asserts that running a day at
+// Parallelism(4) produces the same final ratings and the same
+// Resultlist, in the same order, as running it sequentially.
+func TestParallelMatchesSequentialResults(t *testing.T) {
+	const n = 42
+
+	seq := NewSumocycle(1, 1, divisionRikishi(n), divisionBouts(n))
+	seq.RatingSystem(EloSystem{K: 32})
+	if err := seq.Begin(); err != nil {
+		t.Fatalf("sequential Begin: %v", err)
+	}
+
+	par := NewSumocycle(1, 1, divisionRikishi(n), divisionBouts(n))
+	par.RatingSystem(EloSystem{K: 32})
+	par.Parallelism(4)
+	if err := par.Begin(); err != nil {
+		t.Fatalf("parallel Begin: %v", err)
+	}
+
+	if len(seq.Resultlist) != len(par.Resultlist) {
+		t.Fatalf("Resultlist length mismatch: sequential=%d parallel=%d", len(seq.Resultlist), len(par.Resultlist))
+	}
+
+	for i := range seq.Resultlist {
+		seqResult, parResult := seq.Resultlist[i], par.Resultlist[i]
+		if seqResult.East.RikishiID != parResult.East.RikishiID || seqResult.West.RikishiID != parResult.West.RikishiID {
+			t.Fatalf("Resultlist[%d] bout mismatch: sequential=%+v parallel=%+v", i, seqResult, parResult)
+		}
+		if seqResult.East.ScorePost != parResult.East.ScorePost || seqResult.West.ScorePost != parResult.West.ScorePost {
+			t.Fatalf("Resultlist[%d] score mismatch: sequential=%+v parallel=%+v", i, seqResult, parResult)
+		}
+	}
+
+	for id, seqData := range seq.RikishiData {
+		parData, ok := par.RikishiData[id]
+		if !ok {
+			t.Fatalf("rikishi %d missing from parallel run's RikishiData", id)
+		}
+		if seqData.Rating != parData.Rating {
+			t.Fatalf("rikishi %d rating mismatch: sequential=%v parallel=%v", id, seqData.Rating, parData.Rating)
+		}
+	}
+}
+
+func TestPartitionBoutsKeepsBatchesDisjoint(t *testing.T) {
+	bouts := []sumomodel.Bout{
+		{EastRikishiID: 1, WestRikishiID: 2},
+		{EastRikishiID: 3, WestRikishiID: 4},
+		{EastRikishiID: 1, WestRikishiID: 3}, // shares rikishi 1 and 3 with earlier bouts
+	}
+
+	batches := partitionBouts(bouts)
+
+	for _, batch := range batches {
+		seen := map[int]bool{}
+		for _, idx := range batch {
+			bout := bouts[idx]
+			if seen[bout.EastRikishiID] || seen[bout.WestRikishiID] {
+				t.Fatalf("batch %v has a repeated rikishi ID", batch)
+			}
+			seen[bout.EastRikishiID] = true
+			seen[bout.WestRikishiID] = true
+		}
+	}
+
+	total := 0
+	for _, batch := range batches {
+		total += len(batch)
+	}
+	if total != len(bouts) {
+		t.Fatalf("partitionBouts dropped bouts: got %d of %d", total, len(bouts))
+	}
+}
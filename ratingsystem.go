@@ -0,0 +1,110 @@
+package sumoratinglifecycle
+
+import "math"
+
+type (
+	// Rating carries whatever state a RatingSystem needs to represent a
+	// rikishi's standing. EloSystem uses a plain float64, while systems
+	// like Glicko2System or TrueSkillSystem need multiple parameters and
+	// carry their own struct.
+	Rating interface{}
+
+	// RatingSystem calculates how a Rating changes as a result of a bout.
+	// It replaces the single Calculation func, allowing systems that need
+	// more than one scalar per rikishi (Glicko-2, TrueSkill) to plug in
+	// alongside Elo.
+	RatingSystem interface {
+		// InitialRating returns the Rating a rikishi with no history
+		// should start with.
+		InitialRating() Rating
+
+		// Update computes self's new Rating after a bout against
+		// opponent, given whether self won.
+		Update(self, opponent Rating, selfWon bool) Rating
+
+		// Compare returns a negative number if a ranks below b, zero if
+		// they're equal, and a positive number if a ranks above b.
+		Compare(a, b Rating) int
+
+		// Display returns the scalar score exposed on RikishiBoutResult
+		// for callers that just want a single number to show or store.
+		Display(r Rating) float64
+
+		// BatchesPerDay reports whether this system updates a rikishi's
+		// Rating once per day from every bout they fought, rather than
+		// bout by bout. Systems that return true must also implement
+		// BatchRatingSystem.
+		BatchesPerDay() bool
+	}
+
+	// BatchRatingSystem is implemented by RatingSystems whose
+	// BatchesPerDay returns true. Begin accumulates every bout a rikishi
+	// fought in a day and applies BatchUpdate once, after the day's bouts
+	// have all been calculated, instead of calling Update per bout.
+	BatchRatingSystem interface {
+		RatingSystem
+
+		// BatchUpdate computes self's new Rating from every bout result
+		// self was involved in during the rating period.
+		BatchUpdate(self Rating, results []BatchResult) Rating
+	}
+
+	// BatchResult is one opponent/outcome pair accumulated for a
+	// BatchRatingSystem's rating period.
+	BatchResult struct {
+		Opponent Rating
+		Won      bool
+	}
+)
+
+// EloSystem is the classic Elo rating system, operating on a plain float64
+// Rating. K controls how much a single bout can move a rating.
+type EloSystem struct {
+	K float64
+}
+
+// InitialRating returns 1500, Elo's conventional starting rating.
+func (e EloSystem) InitialRating() Rating {
+	return float64(1500)
+}
+
+// Update applies the standard Elo update: newRating = self + K*(score - expected).
+func (e EloSystem) Update(self, opponent Rating, selfWon bool) Rating {
+	selfScore := self.(float64)
+	opponentScore := opponent.(float64)
+
+	expected := 1 / (1 + math.Pow(10, (opponentScore-selfScore)/400))
+
+	actual := 0.0
+	if selfWon {
+		actual = 1.0
+	}
+
+	return selfScore + e.K*(actual-expected)
+}
+
+// Compare ranks two Elo ratings by their float64 value.
+func (e EloSystem) Compare(a, b Rating) int {
+	return compareFloat64(a.(float64), b.(float64))
+}
+
+// Display returns the Elo rating itself.
+func (e EloSystem) Display(r Rating) float64 {
+	return r.(float64)
+}
+
+// BatchesPerDay is false; Elo updates after every bout.
+func (e EloSystem) BatchesPerDay() bool {
+	return false
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
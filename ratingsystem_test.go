@@ -0,0 +1,145 @@
+package sumoratinglifecycle
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/jph5396/sumomodel"
+)
+
+func TestEloSystemUpdate(t *testing.T) {
+	rs := EloSystem{K: 32}
+
+	got := rs.Update(float64(1500), float64(1500), true).(float64)
+	want := 1516.0
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("EloSystem.Update(1500, 1500, win) = %v, want %v", got, want)
+	}
+
+	if rs.Compare(float64(1600), float64(1500)) <= 0 {
+		t.Fatalf("expected 1600 to compare greater than 1500")
+	}
+	if rs.Display(float64(1234)) != 1234 {
+		t.Fatalf("EloSystem.Display should return the rating unchanged")
+	}
+	if rs.BatchesPerDay() {
+		t.Fatalf("EloSystem should not batch per day")
+	}
+}
+
+func TestGlicko2SystemUpdate(t *testing.T) {
+	// Glickman's own worked example from the Glicko-2 paper: a player
+	// rated 1500/200 plays three opponents in one rating period and
+	// should land at roughly 1464.06/151.52.
+	rs := Glicko2System{Tau: 0.5}
+
+	self := Glicko2Rating{R: 1500, RD: 200, Sigma: 0.06}
+	results := []BatchResult{
+		{Opponent: Glicko2Rating{R: 1400, RD: 30, Sigma: 0.06}, Won: true},
+		{Opponent: Glicko2Rating{R: 1550, RD: 100, Sigma: 0.06}, Won: false},
+		{Opponent: Glicko2Rating{R: 1700, RD: 300, Sigma: 0.06}, Won: false},
+	}
+
+	got := rs.BatchUpdate(self, results).(Glicko2Rating)
+
+	if math.Abs(got.R-1464.06) > 0.1 {
+		t.Fatalf("Glicko2System.BatchUpdate rating = %v, want ~1464.06", got.R)
+	}
+	if math.Abs(got.RD-151.52) > 0.5 {
+		t.Fatalf("Glicko2System.BatchUpdate RD = %v, want ~151.52", got.RD)
+	}
+
+	if !rs.BatchesPerDay() {
+		t.Fatalf("Glicko2System should batch per day")
+	}
+	if rs.Display(got) != got.R {
+		t.Fatalf("Glicko2System.Display should return R")
+	}
+}
+
+func TestTrueSkillSystemUpdate(t *testing.T) {
+	rs := TrueSkillSystem{Mu0: 25, Sigma0: 25.0 / 3, Beta: 25.0 / 6, Tau: 25.0 / 300, DrawProb: 0.1}
+
+	winner := rs.InitialRating().(TrueSkillRating)
+	loser := rs.InitialRating().(TrueSkillRating)
+
+	newWinner := rs.Update(winner, loser, true).(TrueSkillRating)
+	newLoser := rs.Update(loser, winner, false).(TrueSkillRating)
+
+	if newWinner.Mu <= winner.Mu {
+		t.Fatalf("winner's Mu should increase: before=%v after=%v", winner.Mu, newWinner.Mu)
+	}
+	if newLoser.Mu >= loser.Mu {
+		t.Fatalf("loser's Mu should decrease: before=%v after=%v", loser.Mu, newLoser.Mu)
+	}
+	if newWinner.Sigma >= winner.Sigma {
+		t.Fatalf("a bout should narrow the winner's Sigma: before=%v after=%v", winner.Sigma, newWinner.Sigma)
+	}
+
+	if rs.BatchesPerDay() {
+		t.Fatalf("this package's TrueSkillSystem should not batch per day")
+	}
+}
+
+// claimsBatchButCannotBatch reports BatchesPerDay() true without
+// implementing BatchRatingSystem, so BeginContext should reject it
+// instead of silently falling back to per-bout Update.
+type claimsBatchButCannotBatch struct {
+	EloSystem
+}
+
+func (claimsBatchButCannotBatch) BatchesPerDay() bool {
+	return true
+}
+
+func TestBeginContextRejectsBatchesPerDayInconsistentWithBatchRatingSystem(t *testing.T) {
+	rikishi, bouts := twoRikishiOneBoutDay()
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(claimsBatchButCannotBatch{EloSystem: EloSystem{K: 32}})
+
+	if err := s.Begin(); err == nil {
+		t.Fatalf("expected Begin to reject a rating system whose BatchesPerDay() disagrees with BatchRatingSystem implementation")
+	}
+}
+
+func TestBeginAppliesBatchUpdateToRikishiWhoDidNotFight(t *testing.T) {
+	rs := Glicko2System{Tau: 0.5}
+	sittingOut := rs.InitialRating().(Glicko2Rating)
+
+	rikishi := map[int]RikishiData{
+		1: {Rikishi: sumomodel.Rikishi{Id: 1, Name: "one"}, Rating: rs.InitialRating()},
+		2: {Rikishi: sumomodel.Rikishi{Id: 2, Name: "two"}, Rating: rs.InitialRating()},
+		3: {Rikishi: sumomodel.Rikishi{Id: 3, Name: "kyujo"}, Rating: sittingOut},
+	}
+	bouts := []sumomodel.Bout{
+		{BashoID: 1, Day: 1, Boutnum: 1, EastRikishiID: 1, WestRikishiID: 2, EastWin: true},
+	}
+
+	s := NewSumocycle(1, 1, rikishi, bouts)
+	s.RatingSystem(rs)
+
+	if err := s.Begin(); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	got := s.RikishiData[3].Rating.(Glicko2Rating)
+	if got.RD <= sittingOut.RD {
+		t.Fatalf("a sitting-out rikishi's RD should grow via BatchUpdate(self, nil), before=%v after=%v", sittingOut.RD, got.RD)
+	}
+}
+
+func TestCalculateBoutRejectsNilRating(t *testing.T) {
+	s := NewSumocycle(1, 1, map[int]RikishiData{
+		1: {Rikishi: sumomodel.Rikishi{Id: 1}, Rating: nil},
+		2: {Rikishi: sumomodel.Rikishi{Id: 2}, Rating: EloSystem{K: 32}.InitialRating()},
+	}, nil)
+	s.RatingSystem(EloSystem{K: 32})
+
+	bout := sumomodel.Bout{EastRikishiID: 1, WestRikishiID: 2}
+
+	var mu sync.Mutex
+	if _, err := s.calculateBout(bout, &mu, map[int][]BatchResult{}, false); err == nil {
+		t.Fatalf("expected an error for a nil Rating instead of a panic")
+	}
+}
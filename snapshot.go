@@ -0,0 +1,18 @@
+package sumoratinglifecycle
+
+// Snapshot returns a copy of RikishiData and Resultlist as they stand right
+// now, independent of the maps/slices Sumocycle itself keeps mutating. It
+// lets a caller that aborted BeginContext (via ctx cancellation or a hook
+// error) inspect partial progress, or persist it as a checkpoint to resume
+// from later.
+func (s Sumocycle) Snapshot() (map[int]RikishiData, []BoutResult) {
+	rikishi := make(map[int]RikishiData, len(s.RikishiData))
+	for id, data := range s.RikishiData {
+		rikishi[id] = data
+	}
+
+	results := make([]BoutResult, len(s.Resultlist))
+	copy(results, s.Resultlist)
+
+	return rikishi, results
+}
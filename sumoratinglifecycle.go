@@ -1,8 +1,10 @@
 package sumoratinglifecycle
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/jph5396/sumomodel"
 )
@@ -12,7 +14,7 @@ type (
 	// and their rating.
 	RikishiData struct {
 		Rikishi sumomodel.Rikishi
-		Rating  float64
+		Rating  Rating
 	}
 
 	// BoutResult contains data representing the result of a bout.
@@ -33,6 +35,13 @@ type (
 		ScorePre  float64 `firestore:"scorepre,omitempty"`
 		ScorePost float64 `firestore:"scorepost,omitempty"`
 		Change    float64 `firestore:"change,omitempty"`
+
+		// RatingPre and RatingPost carry the full Rating the configured
+		// RatingSystem operates on, which for systems other than Elo
+		// holds more than the display score above (Glicko-2's RD and
+		// volatility, TrueSkill's sigma, etc).
+		RatingPre  Rating `firestore:"-"`
+		RatingPost Rating `firestore:"-"`
 	}
 
 	//Sumocycle contains data and lifecycle hooks to calculate sumo
@@ -44,11 +53,14 @@ type (
 		BoutList    []sumomodel.Bout
 		Resultlist  []BoutResult
 
-		preday    func(*Sumocycle)
-		prebout   func(*sumomodel.Bout, int)
-		calculate func(float64, float64, bool) float64
-		postbout  func(BoutResult)
-		postday   func(Sumocycle)
+		preday   func(*Sumocycle) error
+		prebout  func(*sumomodel.Bout, int) error
+		postbout func(BoutResult) error
+		postday  func(Sumocycle) error
+
+		ratingSystem RatingSystem
+		bus          *EventBus
+		parallelism  int
 	}
 )
 
@@ -59,124 +71,257 @@ func NewSumocycle(basho int, day int, rikishi map[int]RikishiData, boutlist []su
 		Day:         day,
 		RikishiData: rikishi,
 		BoutList:    boutlist,
+		bus:         NewEventBus(),
 	}
 
 	return cycle
 }
 
+// Events returns the EventBus subscribers can use to observe lifecycle
+// stages (BeforeDay, BeforeBout, AfterBout, AfterDay) independently of the
+// legacy hook functions set via BeforeDay, BeforeBout, AfterBout and
+// AfterDay below.
+func (s *Sumocycle) Events() *EventBus {
+	if s.bus == nil {
+		s.bus = NewEventBus()
+	}
+	return s.bus
+}
+
 // BeforeDay sets the function that is executed at the beginning
-// of the basho before any calculations begin
-func (s *Sumocycle) BeforeDay(f func(*Sumocycle)) {
+// of the day before any calculations begin. Returning a non-nil error
+// aborts the cycle before any bout is processed.
+func (s *Sumocycle) BeforeDay(f func(*Sumocycle) error) {
 	s.preday = f
 
 }
 
 // BeforeBout set the function that will execute right before a
-// bout
-func (s *Sumocycle) BeforeBout(f func(*sumomodel.Bout, int)) {
+// bout. Returning a non-nil error aborts the cycle; BeginContext wraps it
+// with the bout's index.
+func (s *Sumocycle) BeforeBout(f func(*sumomodel.Bout, int) error) {
 	s.prebout = f
 }
 
-// AfterBout set the function that will execute after a bout.
-func (s *Sumocycle) AfterBout(f func(BoutResult)) {
+// AfterBout set the function that will execute after a bout. Returning a
+// non-nil error aborts the cycle; BeginContext wraps it with the bout's
+// index.
+func (s *Sumocycle) AfterBout(f func(BoutResult) error) {
 	s.postbout = f
 }
 
 // AfterDay set the function that will execute after all bout calculations
 // have been completed. It is a good time to save data if desired.
-func (s *Sumocycle) AfterDay(f func(Sumocycle)) {
+// Returning a non-nil error aborts the cycle.
+func (s *Sumocycle) AfterDay(f func(Sumocycle) error) {
 	s.postday = f
 
 }
 
-// Calculation set the function that calculates the rating.
-// the provided function accepts two floats and a bool.
-// the first float represents the current rikishi and the second represents
-// their opponent.
-func (s *Sumocycle) Calculation(f func(float64, float64, bool) float64) {
-	s.calculate = f
+// RatingSystem sets the RatingSystem used to calculate rikishi ratings.
+// It replaces the old Calculation func, since systems like Glicko-2 and
+// TrueSkill need more than two floats and a bool to describe a rikishi's
+// standing.
+func (s *Sumocycle) RatingSystem(rs RatingSystem) {
+	s.ratingSystem = rs
+}
+
+// Parallelism sets how many bouts Begin may calculate concurrently. n <= 1
+// (the default) keeps the original sequential behavior; n > 1 switches
+// Begin to a worker-pool path that computes disjoint bouts in parallel
+// while still applying each rikishi's updates and emitting results in
+// original bout order.
+func (s *Sumocycle) Parallelism(n int) {
+	s.parallelism = n
+}
+
+// calculateBout computes the RikishiBoutResult for a single bout. mu guards
+// reads and writes of s.RikishiData (and pending, for batching rating
+// systems) so the sequential and parallel Begin paths can share it safely.
+func (s *Sumocycle) calculateBout(bout sumomodel.Bout, mu *sync.Mutex, pending map[int][]BatchResult, isBatch bool) (BoutResult, error) {
+	mu.Lock()
+	east, ok := s.RikishiData[bout.EastRikishiID]
+	if !ok {
+		mu.Unlock()
+		return BoutResult{}, fmt.Errorf("Rikishi with id %v was not provided but appears in a bout", bout.EastRikishiID)
+	}
+
+	west, ok := s.RikishiData[bout.WestRikishiID]
+	if !ok {
+		mu.Unlock()
+		return BoutResult{}, fmt.Errorf("Rikishi with id %v was not provided but appears in a bout", bout.WestRikishiID)
+	}
+	mu.Unlock()
+
+	// Rating is an interface now, so a RikishiData left at its zero value
+	// instead of being seeded via RatingSystem.InitialRating() carries a
+	// nil Rating. Catch that here with a clear error instead of letting
+	// the RatingSystem's type assertion panic.
+	if east.Rating == nil {
+		return BoutResult{}, fmt.Errorf("Rikishi with id %v has a nil Rating; seed it with RatingSystem.InitialRating()", bout.EastRikishiID)
+	}
+	if west.Rating == nil {
+		return BoutResult{}, fmt.Errorf("Rikishi with id %v has a nil Rating; seed it with RatingSystem.InitialRating()", bout.WestRikishiID)
+	}
+
+	// Systems that batch a rating period (e.g. Glicko2System) don't move
+	// a rikishi's Rating until every bout they fought that day has been
+	// seen, so ScorePost/RatingPost below carry the pre-update Rating;
+	// BeginContext applies the batch to RikishiData once every bout has
+	// been calculated, before AfterDay runs.
+	eastNewRating := east.Rating
+	westNewRating := west.Rating
+	if isBatch {
+		mu.Lock()
+		pending[east.Rikishi.Id] = append(pending[east.Rikishi.Id], BatchResult{Opponent: west.Rating, Won: bout.EastWin})
+		pending[west.Rikishi.Id] = append(pending[west.Rikishi.Id], BatchResult{Opponent: east.Rating, Won: bout.WestWin})
+		mu.Unlock()
+	} else {
+		eastNewRating = s.ratingSystem.Update(east.Rating, west.Rating, bout.EastWin)
+		westNewRating = s.ratingSystem.Update(west.Rating, east.Rating, bout.WestWin)
+	}
+
+	eastScorePre := s.ratingSystem.Display(east.Rating)
+	eastScorePost := s.ratingSystem.Display(eastNewRating)
+	westScorePre := s.ratingSystem.Display(west.Rating)
+	westScorePost := s.ratingSystem.Display(westNewRating)
+
+	newBoutResult := BoutResult{
+		BashoID: bout.BashoID,
+		Day:     bout.Day,
+		BoutNum: bout.Boutnum,
+		East: RikishiBoutResult{
+			RikishiID:  east.Rikishi.Id,
+			Name:       east.Rikishi.Name,
+			Rank:       east.Rikishi.Rank,
+			ScorePre:   eastScorePre,
+			ScorePost:  eastScorePost,
+			Change:     eastScorePost - eastScorePre,
+			RatingPre:  east.Rating,
+			RatingPost: eastNewRating,
+		},
+		West: RikishiBoutResult{
+			RikishiID:  west.Rikishi.Id,
+			Name:       west.Rikishi.Name,
+			Rank:       west.Rikishi.Rank,
+			ScorePre:   westScorePre,
+			ScorePost:  westScorePost,
+			Change:     westScorePost - westScorePre,
+			RatingPre:  west.Rating,
+			RatingPost: westNewRating,
+		},
+	}
+
+	east.Rating = eastNewRating
+	west.Rating = westNewRating
+
+	mu.Lock()
+	s.RikishiData[east.Rikishi.Id] = east
+	s.RikishiData[west.Rikishi.Id] = west
+	mu.Unlock()
+
+	return newBoutResult, nil
 }
 
 // Begin checks if all required functions on the Sumocycle object are set.
-// if yes, it begins the process. If no, it returns an error.
+// if yes, it begins the process. If no, it returns an error. It is
+// equivalent to BeginContext(context.Background()).
 func (s *Sumocycle) Begin() error {
+	return s.BeginContext(context.Background())
+}
+
+// BeginContext is Begin, but aborts promptly once ctx is cancelled or its
+// deadline passes, and propagates any error returned by a hook function.
+// Errors encountered while processing a given bout - from BeforeBout, the
+// rating calculation, AfterBout, or ctx itself - are wrapped with the
+// bout's index via fmt.Errorf("bout %d: %w", i, err).
+func (s *Sumocycle) BeginContext(ctx context.Context) error {
 	err := s.validate()
 	if err != nil {
 		return err
 	}
 
 	if s.preday != nil {
-		s.preday(s)
+		if err := s.preday(s); err != nil {
+			return err
+		}
+	}
+	if err := s.Events().Publish(BeforeDay, BeforeDayEvent{Cycle: s}); err != nil {
+		return err
 	}
 
-	for i, bout := range s.BoutList {
-		if s.prebout != nil {
-			s.prebout(&bout, i)
-		}
-		// Gather Rikishi Data
-		east, ok := s.RikishiData[bout.EastRikishiID]
-		if !ok {
-			return fmt.Errorf("Rikishi with id %v was not provided but appears in a bout", bout.EastRikishiID)
-		}
+	isBatch := s.ratingSystem.BatchesPerDay()
+	batching, implementsBatch := s.ratingSystem.(BatchRatingSystem)
+	if isBatch && !implementsBatch {
+		return fmt.Errorf("rating system %T reports BatchesPerDay() but does not implement BatchRatingSystem", s.ratingSystem)
+	}
+	pending := map[int][]BatchResult{}
 
-		west, ok := s.RikishiData[bout.WestRikishiID]
-		if !ok {
-			return fmt.Errorf("Rikishi with id %v was not provided but appears in a bout", bout.WestRikishiID)
+	if s.parallelism > 1 {
+		if err := s.runParallel(ctx, pending, isBatch); err != nil {
+			return err
 		}
+	} else {
+		var mu sync.Mutex
+		for i, bout := range s.BoutList {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("bout %d: %w", i, err)
+			}
 
-		eastNewScore := s.calculate(east.Rating, west.Rating, bout.EastWin)
-		westNewScore := s.calculate(west.Rating, east.Rating, bout.WestWin)
-
-		// build
-		eastBoutResult := RikishiBoutResult{
-			RikishiID: east.Rikishi.Id,
-			Name:      east.Rikishi.Name,
-			Rank:      east.Rikishi.Rank,
-			ScorePre:  east.Rating,
-			ScorePost: eastNewScore,
-			Change:    eastNewScore - east.Rating,
-		}
+			if s.prebout != nil {
+				if err := s.prebout(&bout, i); err != nil {
+					return fmt.Errorf("bout %d: %w", i, err)
+				}
+			}
+			if err := s.Events().Publish(BeforeBout, BeforeBoutEvent{Bout: &bout, Index: i}); err != nil {
+				return fmt.Errorf("bout %d: %w", i, err)
+			}
 
-		westBoutResult := RikishiBoutResult{
-			RikishiID: west.Rikishi.Id,
-			Name:      west.Rikishi.Name,
-			Rank:      west.Rikishi.Rank,
-			ScorePre:  west.Rating,
-			ScorePost: westNewScore,
-			Change:    westNewScore - west.Rating,
-		}
+			newBoutResult, err := s.calculateBout(bout, &mu, pending, isBatch)
+			if err != nil {
+				return fmt.Errorf("bout %d: %w", i, err)
+			}
 
-		newBoutResult := BoutResult{
-			BashoID: bout.BashoID,
-			Day:     bout.Day,
-			BoutNum: bout.Boutnum,
-			East:    eastBoutResult,
-			West:    westBoutResult,
-		}
+			//Execute the postbout function if one exists.
+			if s.postbout != nil {
+				if err := s.postbout(newBoutResult); err != nil {
+					return fmt.Errorf("bout %d: %w", i, err)
+				}
+			}
+			if err := s.Events().Publish(AfterBout, AfterBoutEvent{Result: newBoutResult}); err != nil {
+				return fmt.Errorf("bout %d: %w", i, err)
+			}
 
-		//Execute the postbout function if one exists.
-		if s.postbout != nil {
-			s.postbout(newBoutResult)
+			s.Resultlist = append(s.Resultlist, newBoutResult)
 		}
+	}
 
-		east.Rating = eastNewScore
-		west.Rating = westNewScore
-		s.RikishiData[east.Rikishi.Id] = east
-		s.RikishiData[west.Rikishi.Id] = west
-
-		s.Resultlist = append(s.Resultlist, newBoutResult)
+	if isBatch {
+		// Apply BatchUpdate to every rikishi, not just those with an entry
+		// in pending: a rikishi who sat out the whole day (kyujo) still
+		// needs to go through the batch path so systems like Glicko2System
+		// can grow RD for inactivity even though pending[id] is nil/empty.
+		for id, data := range s.RikishiData {
+			data.Rating = batching.BatchUpdate(data.Rating, pending[id])
+			s.RikishiData[id] = data
+		}
 	}
 
 	if s.postday != nil {
-		s.postday(*s)
+		if err := s.postday(*s); err != nil {
+			return err
+		}
+	}
+	if err := s.Events().Publish(AfterDay, AfterDayEvent{Cycle: *s}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (s Sumocycle) validate() error {
-	if s.calculate == nil {
-		return errors.New("no calculate function set")
+	if s.ratingSystem == nil {
+		return errors.New("no rating system set")
 	}
 
 	if len(s.RikishiData) == 0 {
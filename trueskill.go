@@ -0,0 +1,125 @@
+package sumoratinglifecycle
+
+import "math"
+
+// TrueSkillRating is the Rating carried by TrueSkillSystem: a belief about
+// skill expressed as a Gaussian with mean Mu and standard deviation Sigma.
+type TrueSkillRating struct {
+	Mu    float64
+	Sigma float64
+}
+
+// TrueSkillSystem implements a two-player simplification of Microsoft's
+// TrueSkill rating system. Mu0/Sigma0 seed new rikishi, Beta is the
+// performance variance, Tau is the additive dynamics factor applied each
+// update to keep ratings tracking a rikishi's current skill, and DrawProb
+// is the probability two evenly matched rikishi draw (sumo has no draws,
+// but the math needs a value to compute the draw margin epsilon).
+type TrueSkillSystem struct {
+	Mu0      float64
+	Sigma0   float64
+	Beta     float64
+	Tau      float64
+	DrawProb float64
+}
+
+// InitialRating returns a TrueSkillRating seeded from Mu0/Sigma0.
+func (t TrueSkillSystem) InitialRating() Rating {
+	return TrueSkillRating{Mu: t.Mu0, Sigma: t.Sigma0}
+}
+
+// Update applies one TrueSkill factor-graph update for a two-player bout.
+func (t TrueSkillSystem) Update(self, opponent Rating, selfWon bool) Rating {
+	s := self.(TrueSkillRating)
+	o := opponent.(TrueSkillRating)
+
+	selfVar := s.Sigma*s.Sigma + t.Tau*t.Tau
+	oppVar := o.Sigma*o.Sigma + t.Tau*t.Tau
+
+	c := math.Sqrt(selfVar + oppVar + 2*t.Beta*t.Beta)
+
+	diff := s.Mu - o.Mu
+	if !selfWon {
+		diff = -diff
+	}
+
+	eps := t.drawMargin(c)
+	t1 := (diff - eps) / c
+
+	v := vExceedsMargin(t1)
+	w := wExceedsMargin(t1, v)
+
+	sign := 1.0
+	if !selfWon {
+		sign = -1.0
+	}
+
+	muPrime := s.Mu + sign*(selfVar/c)*v
+	sigmaSqPrime := selfVar * (1 - (selfVar/(c*c))*w)
+
+	return TrueSkillRating{Mu: muPrime, Sigma: math.Sqrt(math.Max(sigmaSqPrime, 0))}
+}
+
+// drawMargin converts DrawProb into the epsilon margin TrueSkill's draw
+// handling expects, scaled by the combined performance variance c.
+func (t TrueSkillSystem) drawMargin(c float64) float64 {
+	return invCDF((t.DrawProb+1)/2) * c
+}
+
+// vExceedsMargin and wExceedsMargin are the truncated-Gaussian correction
+// functions from the TrueSkill paper's "win" case.
+func vExceedsMargin(t float64) float64 {
+	denom := cdf(t)
+	if denom < 2.222758749e-162 {
+		return -t
+	}
+	return pdf(t) / denom
+}
+
+func wExceedsMargin(t, v float64) float64 {
+	return v * (v + t)
+}
+
+func pdf(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func cdf(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// invCDF approximates the inverse of the standard normal CDF via 50
+// Newton-Raphson iterations starting from 0.
+func invCDF(p float64) float64 {
+	if p <= 0 {
+		return math.Inf(-1)
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+
+	x := 0.0
+	for i := 0; i < 50; i++ {
+		x -= (cdf(x) - p) / pdf(x)
+	}
+	return x
+}
+
+// Compare ranks two TrueSkill ratings by a conservative skill estimate
+// (mu - 3*sigma), the same heuristic TrueSkill leaderboards use.
+func (t TrueSkillSystem) Compare(a, b Rating) int {
+	aR := a.(TrueSkillRating)
+	bR := b.(TrueSkillRating)
+	return compareFloat64(aR.Mu-3*aR.Sigma, bR.Mu-3*bR.Sigma)
+}
+
+// Display returns Mu, the rating's mean skill estimate.
+func (t TrueSkillSystem) Display(r Rating) float64 {
+	return r.(TrueSkillRating).Mu
+}
+
+// BatchesPerDay is false: this package's TrueSkillSystem updates head to
+// head, one bout at a time.
+func (t TrueSkillSystem) BatchesPerDay() bool {
+	return false
+}